@@ -0,0 +1,196 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy controls what an AsyncWriter does once its queue is full.
+type OverflowPolicy int
+
+const (
+	// Block waits for room in the queue.
+	Block OverflowPolicy = iota
+
+	// DropNewest discards the entry that didn't fit.
+	DropNewest
+
+	// DropOldest discards the oldest queued entry to make room for the new one.
+	DropOldest
+)
+
+// AsyncStats reports AsyncWriter queue activity.
+type AsyncStats struct {
+	Queued  uint64
+	Dropped uint64
+	Flushed uint64
+}
+
+type asyncJob struct {
+	entry     *Entry
+	formatter Formatter
+	out       io.Writer
+	pool      *sync.Pool
+	hookLevel int
+}
+
+// AsyncWriter moves log formatting and I/O off the calling goroutine onto a
+// single background goroutine, via a bounded queue of pooled *Entry values.
+type AsyncWriter struct {
+	queue    chan asyncJob
+	overflow OverflowPolicy
+	wg       sync.WaitGroup
+
+	queued  uint64
+	dropped uint64
+	flushed uint64
+}
+
+// NewAsyncWriter starts a background goroutine that drains a bounded queue
+// of log entries, formatting and writing each one as it comes off the
+// queue. onOverflow controls behavior once the queue is full.
+func NewAsyncWriter(bufferSize int, onOverflow OverflowPolicy) *AsyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = 1024
+	}
+	aw := &AsyncWriter{
+		queue:    make(chan asyncJob, bufferSize),
+		overflow: onOverflow,
+	}
+	aw.wg.Add(1)
+	go aw.run()
+	return aw
+}
+
+func (aw *AsyncWriter) run() {
+	defer aw.wg.Done()
+	for job := range aw.queue {
+		if err := job.formatter.Format(job.entry, job.out); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+		} else {
+			fireHooks(job.hookLevel, job.entry)
+		}
+		job.pool.Put(job.entry)
+		atomic.AddUint64(&aw.flushed, 1)
+	}
+}
+
+func (aw *AsyncWriter) enqueue(entry *Entry, formatter Formatter, out io.Writer, pool *sync.Pool, hookLevel int) {
+	job := asyncJob{entry: entry, formatter: formatter, out: out, pool: pool, hookLevel: hookLevel}
+	atomic.AddUint64(&aw.queued, 1)
+
+	select {
+	case aw.queue <- job:
+		return
+	default:
+	}
+
+	switch aw.overflow {
+	case Block:
+		aw.queue <- job
+	case DropNewest:
+		atomic.AddUint64(&aw.dropped, 1)
+		pool.Put(entry)
+	case DropOldest:
+		select {
+		case old := <-aw.queue:
+			atomic.AddUint64(&aw.dropped, 1)
+			old.pool.Put(old.entry)
+		default:
+		}
+		select {
+		case aw.queue <- job:
+		default:
+			atomic.AddUint64(&aw.dropped, 1)
+			pool.Put(entry)
+		}
+	}
+}
+
+// Stats returns a snapshot of the queue counters.
+func (aw *AsyncWriter) Stats() AsyncStats {
+	return AsyncStats{
+		Queued:  atomic.LoadUint64(&aw.queued),
+		Dropped: atomic.LoadUint64(&aw.dropped),
+		Flushed: atomic.LoadUint64(&aw.flushed),
+	}
+}
+
+// Flush blocks until every entry queued so far has been written or dropped,
+// or ctx is done.
+func (aw *AsyncWriter) Flush(ctx context.Context) error {
+	target := atomic.LoadUint64(&aw.queued)
+	for atomic.LoadUint64(&aw.flushed)+atomic.LoadUint64(&aw.dropped) < target {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			runtime.Gosched()
+		}
+	}
+	return nil
+}
+
+// Close flushes everything already queued, then stops the background
+// goroutine. Callers must not enqueue into this AsyncWriter (i.e. log
+// through it) once Close has been called.
+func (aw *AsyncWriter) Close() error {
+	err := aw.Flush(context.Background())
+	close(aw.queue)
+	aw.wg.Wait()
+	return err
+}
+
+var defaultAsync *AsyncWriter
+
+// SetAsync moves formatting and I/O for every currently configured, and
+// every subsequently created, output onto a background goroutine buffered
+// by a bounded queue of size bufferSize. onOverflow controls what happens
+// once that queue fills up. Replacing a previous SetAsync configuration
+// flushes and stops its background goroutine rather than leaking it.
+func SetAsync(bufferSize int, onOverflow OverflowPolicy) {
+	lock.Lock()
+	old := defaultAsync
+	defaultAsync = NewAsyncWriter(bufferSize, onOverflow)
+	for _, w := range logOut {
+		w.async = defaultAsync
+	}
+	lock.Unlock()
+
+	if old != nil {
+		_ = old.Close()
+	}
+}
+
+// flushAsync flushes the default AsyncWriter, if one is configured. Called
+// from Rotate so buffered entries aren't lost across the rotation.
+func flushAsync() {
+	lock.Lock()
+	aw := defaultAsync
+	lock.Unlock()
+	if aw != nil {
+		_ = aw.Flush(context.Background())
+	}
+}
+
+// stopAsync flushes and stops the default AsyncWriter, if one is
+// configured, and clears it so every output falls back to synchronous
+// writes. Called from Close, as the final program-level teardown.
+func stopAsync() {
+	lock.Lock()
+	aw := defaultAsync
+	defaultAsync = nil
+	for _, w := range logOut {
+		w.async = nil
+	}
+	lock.Unlock()
+
+	if aw != nil {
+		_ = aw.Close()
+	}
+}
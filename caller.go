@@ -0,0 +1,66 @@
+package log
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Caller describes the call site that produced a log Entry.
+type Caller struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function"`
+}
+
+const maximumCallerDepth = 25
+
+var logPackage string
+
+func init() {
+	pcs := make([]uintptr, maximumCallerDepth)
+	depth := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:depth])
+	for {
+		frame, more := frames.Next()
+		if pkg := getPackageName(frame.Function); pkg != "" {
+			logPackage = pkg
+			break
+		}
+		if !more {
+			break
+		}
+	}
+}
+
+// getCaller returns the first frame outside of this package, i.e. the
+// caller's call site, or nil if it can't be determined.
+func getCaller() *Caller {
+	pcs := make([]uintptr, maximumCallerDepth)
+	depth := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:depth])
+
+	for f, again := frames.Next(); ; f, again = frames.Next() {
+		if getPackageName(f.Function) != logPackage {
+			return &Caller{File: f.File, Line: f.Line, Function: f.Function}
+		}
+		if !again {
+			break
+		}
+	}
+	return nil
+}
+
+// getPackageName strips the trailing function/method name from a fully
+// qualified function name, leaving the package path.
+func getPackageName(f string) string {
+	for {
+		lastPeriod := strings.LastIndex(f, ".")
+		lastSlash := strings.LastIndex(f, "/")
+		if lastPeriod > lastSlash {
+			f = f[:lastPeriod]
+		} else {
+			break
+		}
+	}
+	return f
+}
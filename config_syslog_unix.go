@@ -0,0 +1,17 @@
+//go:build !windows
+
+package log
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// newSyslogWriter dials a syslog daemon. An empty address connects to the
+// local syslog service; otherwise it's a "host:port" UDP address.
+func newSyslogWriter(address string) (io.Writer, error) {
+	if address == "" {
+		return syslog.New(syslog.LOG_INFO, program)
+	}
+	return syslog.Dial("udp", address, syslog.LOG_INFO, program)
+}
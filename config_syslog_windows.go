@@ -0,0 +1,13 @@
+//go:build windows
+
+package log
+
+import (
+	"fmt"
+	"io"
+)
+
+// newSyslogWriter is unavailable on windows, which has no syslog service.
+func newSyslogWriter(address string) (io.Writer, error) {
+	return nil, fmt.Errorf("log: syslog output is not supported on windows")
+}
@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+	colorGray   = "\033[90m"
+)
+
+// ConsoleFormatter renders human-friendly, level-colored output when the
+// destination io.Writer is a terminal, falling back to plain text otherwise.
+type ConsoleFormatter struct {
+	// ForceColors forces colored output even when the writer isn't a TTY.
+	ForceColors bool
+
+	// DisableColors forces plain output even when the writer is a TTY.
+	DisableColors bool
+
+	// EnvironmentOverrideColors makes the NO_COLOR/CLICOLOR environment
+	// variables take precedence over ForceColors/DisableColors.
+	EnvironmentOverrideColors bool
+
+	// TimestampFormat is the time.Format layout used for the timestamp.
+	// Defaults to time.RFC3339.
+	TimestampFormat string
+}
+
+func (p *ConsoleFormatter) Format(entry *Entry, w io.Writer) error {
+	b, err := json.Marshal(entry.Message)
+	if err != nil {
+		return err
+	}
+
+	ts := entry.Time
+	if p.TimestampFormat != "" {
+		if t, parseErr := time.Parse(time.RFC3339, entry.Time); parseErr == nil {
+			ts = t.Format(p.TimestampFormat)
+		}
+	}
+
+	level := entry.Level.String()
+	if p.colorsEnabled(w) {
+		level = p.colorFor(entry.Level) + level + colorReset
+		ts = colorGray + ts + colorReset
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s [%s] %s", ts, level, b)
+	if entry.Caller != nil {
+		fmt.Fprintf(&buf, " caller=%s:%d", entry.Caller.File, entry.Caller.Line)
+	}
+	if len(entry.Fields) > 0 {
+		keys := make([]string, 0, len(entry.Fields))
+		for k := range entry.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&buf, " %s=%v", k, entry.Fields[k])
+		}
+	}
+	buf.WriteByte('\n')
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func (p *ConsoleFormatter) colorFor(level Level) string {
+	switch {
+	case level >= LevelError:
+		return colorRed
+	case level == LevelWarn:
+		return colorYellow
+	case level <= LevelDebug:
+		return colorGray
+	default:
+		return colorBlue
+	}
+}
+
+func (p *ConsoleFormatter) colorsEnabled(w io.Writer) bool {
+	if p.EnvironmentOverrideColors {
+		if os.Getenv("NO_COLOR") != "" {
+			return false
+		}
+		if os.Getenv("CLICOLOR") == "0" {
+			return false
+		}
+	}
+	if p.DisableColors {
+		return false
+	}
+	if p.ForceColors {
+		return true
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if !term.IsTerminal(int(f.Fd())) {
+		return false
+	}
+	return enableVT(f)
+}
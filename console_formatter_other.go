@@ -0,0 +1,11 @@
+//go:build !windows
+
+package log
+
+import "os"
+
+// enableVT is a no-op on platforms whose terminals already understand ANSI
+// escape sequences.
+func enableVT(f *os.File) bool {
+	return true
+}
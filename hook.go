@@ -0,0 +1,87 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Hook is implemented by sinks that want to receive every log Entry after it
+// has been formatted, such as Logstash, Syslog, Sentry, or Kafka shippers.
+type Hook interface {
+	// Levels returns the levels this hook fires for. An empty slice means
+	// the hook fires for every level.
+	Levels() []int
+
+	// Fire is called with the formatted entry. The Entry passed to Fire is
+	// a copy the hook owns and may retain past the call, e.g. to batch it
+	// up for a Kafka or HTTP shipper; it is never reused or mutated by the
+	// logger afterwards.
+	Fire(*Entry) error
+}
+
+// SyncHook is implemented by hooks that buffer entries and need an explicit
+// flush, e.g. on Rotate/Close.
+type SyncHook interface {
+	Hook
+
+	// Sync flushes any buffered entries.
+	Sync() error
+}
+
+var (
+	hooks   []Hook
+	hooksMu sync.RWMutex
+)
+
+// AddHook registers a Hook that fires after every successfully formatted log entry.
+func AddHook(hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// ClearHooks removes all registered hooks.
+func ClearHooks() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = nil
+}
+
+// SyncHooks flushes every registered hook that implements SyncHook.
+func SyncHooks() {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	for _, h := range hooks {
+		if sh, ok := h.(SyncHook); ok {
+			if err := sh.Sync(); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to sync hook, %v\n", err)
+			}
+		}
+	}
+}
+
+func fireHooks(level int, entry *Entry) {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	for _, h := range hooks {
+		levels := h.Levels()
+		fire := len(levels) == 0
+		for _, lv := range levels {
+			if lv == level {
+				fire = true
+				break
+			}
+		}
+		if !fire {
+			continue
+		}
+		// entry is pool-managed and goes back into the pool (to be
+		// overwritten by the next log call) as soon as fireHooks returns,
+		// so hand each hook its own copy rather than the live value.
+		e := *entry
+		if err := h.Fire(&e); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to fire hook, %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package log
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+type testHook struct {
+	mu      sync.Mutex
+	levels  []int
+	entries []Entry
+	synced  bool
+}
+
+func (h *testHook) Levels() []int { return h.levels }
+
+func (h *testHook) Fire(e *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, *e)
+	return nil
+}
+
+func (h *testHook) Sync() error {
+	h.synced = true
+	return nil
+}
+
+func TestHook_FiltersByEntrySeverity(t *testing.T) {
+	defer ClearHooks()
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+
+	h := &testHook{levels: []int{int(LevelError)}}
+	AddHook(h)
+
+	l := New(0, "")
+	l.Print("info, should not fire the hook")
+	l.Error("error, should fire the hook")
+
+	if len(h.entries) != 1 || h.entries[0].Message != "error, should fire the hook" {
+		t.Fatalf("expected exactly one hook invocation for the Error call, got %+v", h.entries)
+	}
+}
+
+func TestHook_IgnoresLoggerVerbosity(t *testing.T) {
+	defer ClearHooks()
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+	SetLevel(2)
+	defer SetLevel(0)
+
+	// A hook asking for level 2 should never fire just because it was
+	// logged through a V(2) logger; it fires on entry.Level (LevelInfo
+	// here), which V(n).Print always writes regardless of n.
+	h := &testHook{levels: []int{2}}
+	AddHook(h)
+
+	V(2).Print("a plain Print through V(2), not a level-2 severity")
+
+	if len(h.entries) != 0 {
+		t.Fatalf("expected hook not to fire for a logger verbosity match, got %+v", h.entries)
+	}
+}
+
+func TestHook_EmptyLevelsFiresForEverything(t *testing.T) {
+	defer ClearHooks()
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+
+	h := &testHook{}
+	AddHook(h)
+
+	l := New(0, "")
+	l.Print("a")
+	l.Error("b")
+
+	if len(h.entries) != 2 {
+		t.Fatalf("expected a hook with empty Levels() to fire for every entry, got %d", len(h.entries))
+	}
+}
+
+func TestClearHooks(t *testing.T) {
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+
+	h := &testHook{}
+	AddHook(h)
+	ClearHooks()
+
+	New(0, "").Print("x")
+	if len(h.entries) != 0 {
+		t.Fatalf("expected ClearHooks to remove all hooks, got %+v", h.entries)
+	}
+}
+
+func TestSyncHooks(t *testing.T) {
+	defer ClearHooks()
+
+	h := &testHook{}
+	AddHook(h)
+	SyncHooks()
+
+	if !h.synced {
+		t.Fatal("expected SyncHooks to call Sync on a registered SyncHook")
+	}
+}
@@ -8,5 +8,20 @@ import (
 type JSONFormatter struct{}
 
 func (p *JSONFormatter) Format(entry *Entry, w io.Writer) error {
-	return json.NewEncoder(w).Encode(entry)
+	if len(entry.Fields) == 0 {
+		return json.NewEncoder(w).Encode(entry)
+	}
+	data := make(map[string]interface{}, len(entry.Fields)+2)
+	for k, v := range entry.Fields {
+		data[k] = v
+	}
+	data["time"] = entry.Time
+	data["msg"] = entry.Message
+	if entry.Level != LevelInfo {
+		data["level"] = entry.Level.String()
+	}
+	if entry.Caller != nil {
+		data["caller"] = entry.Caller
+	}
+	return json.NewEncoder(w).Encode(data)
 }
@@ -39,9 +39,27 @@ type Logger interface {
 	// verbosity level means a log message is less important.
 	V(level int) InfoLogger
 
+	// Debug logs a debug message, lower severity than Info.
+	Debug(a ...interface{})
+
+	// Debugf logs a formatted debug message.
+	Debugf(format string, a ...interface{})
+
+	// Warn logs a warning message, higher severity than Info.
+	Warn(a ...interface{})
+
+	// Warnf logs a formatted warning message.
+	Warnf(format string, a ...interface{})
+
 	// NewWithPrefix returns a Logger which prefixes all messages.
 	NewWithPrefix(prefix string) Logger
 	WithFormatter(v Formatter) Logger
+
+	// WithField returns a Logger that attaches key/value to every message it logs.
+	WithField(key string, value interface{}) Logger
+
+	// WithFields returns a Logger that attaches the given fields to every message it logs.
+	WithFields(fields map[string]interface{}) Logger
 }
 
 var (
@@ -58,7 +76,8 @@ var (
 )
 
 type Write struct {
-	out io.Writer
+	out   io.Writer
+	async *AsyncWriter
 }
 
 type Log struct {
@@ -68,6 +87,12 @@ type Log struct {
 	Prefix    string
 	Level     int
 	Formatter Formatter
+	Fields    map[string]interface{}
+
+	// ReportCaller, when true, populates Entry.Caller with the file, line
+	// and function of the log call site. Off by default since walking the
+	// call stack has a cost.
+	ReportCaller bool
 }
 type Entry struct {
 	// Time at which the log entry was created
@@ -75,6 +100,16 @@ type Entry struct {
 
 	// Message passed to  Info,  Error
 	Message interface{} `json:"msg"`
+
+	// Level is the named severity the entry was logged at.
+	Level Level `json:"level,omitempty"`
+
+	// Fields carries the structured key/value pairs attached via
+	// WithField/WithFields, if any.
+	Fields map[string]interface{} `json:"-"`
+
+	// Caller is the log call site, populated when the Log has ReportCaller set.
+	Caller *Caller `json:"caller,omitempty"`
 }
 type Formatter interface {
 	Format(*Entry, io.Writer) error
@@ -145,24 +180,58 @@ func (p *Log) WithFormatter(v Formatter) Logger {
 	p.Formatter = v
 	return p
 }
+
+// WithField returns a Logger that attaches key/value to every message it logs.
+func (p *Log) WithField(key string, value interface{}) Logger {
+	return p.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields returns a Logger that attaches the given fields to every message it logs.
+func (p *Log) WithFields(fields map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(p.Fields)+len(fields))
+	for k, v := range p.Fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	np := *p
+	np.Fields = merged
+	return &np
+}
 func (p Log) Outputf(w io.Writer, format string, a ...interface{}) {
-	if logLevel >= p.Level {
+	p.outputf(LevelInfo, w, format, a...)
+}
+
+func (p Log) outputf(level Level, w io.Writer, format string, a ...interface{}) {
+	if verbosityEnabled(p.Level) {
 		entry := p.pool.Get().(*Entry)
 		entry.Time = time.Now().Format(time.RFC3339)
-		entry.Message = fmt.Sprintf(format, a...)
-
-		err := p.Formatter.Format(entry, w)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+		entry.Level = level
+		entry.Fields = p.Fields
+		entry.Caller = nil
+		if p.ReportCaller {
+			entry.Caller = getCaller()
 		}
+		entry.Message = fmt.Sprintf(format, a...)
 
-		p.pool.Put(entry)
+		dispatch(w, entry, p.Formatter, p.pool, int(entry.Level))
 	}
 }
 func (p Log) Output(w io.Writer, a ...interface{}) {
-	if logLevel >= p.Level {
+	p.output(LevelInfo, w, a...)
+}
+
+func (p Log) output(level Level, w io.Writer, a ...interface{}) {
+	if verbosityEnabled(p.Level) {
 		entry := p.pool.Get().(*Entry)
 		entry.Time = time.Now().Format(time.RFC3339)
+		entry.Level = level
+		entry.Fields = p.Fields
+		entry.Caller = nil
+		if p.ReportCaller {
+			entry.Caller = getCaller()
+		}
 
 		b := make([]interface{}, len(a))
 		for i, arg := range a {
@@ -183,14 +252,28 @@ func (p Log) Output(w io.Writer, a ...interface{}) {
 			entry.Message = b
 		}
 
-		err := p.Formatter.Format(entry, w)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
-		}
-		p.pool.Put(entry)
+		dispatch(w, entry, p.Formatter, p.pool, int(entry.Level))
 	}
 }
 
+// dispatch formats and writes entry, either synchronously or, if w is a
+// *Write configured with SetAsync, by handing it off to that Write's
+// AsyncWriter to be formatted and written on a background goroutine.
+func dispatch(w io.Writer, entry *Entry, formatter Formatter, pool *sync.Pool, hookLevel int) {
+	if wr, ok := w.(*Write); ok && wr.async != nil {
+		wr.async.enqueue(entry, formatter, wr.out, pool, hookLevel)
+		return
+	}
+
+	err := formatter.Format(entry, w)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+	} else {
+		fireHooks(hookLevel, entry)
+	}
+	pool.Put(entry)
+}
+
 // Printf calls l.Output to print to the logger.
 // Arguments are handled in the manner of fmt.Printf.
 func (p Log) Printf(format string, a ...interface{}) {
@@ -200,15 +283,37 @@ func (p Log) Print(a ...interface{}) {
 	p.Output(p.info, a...)
 }
 func (p Log) Errorf(format string, a ...interface{}) {
-	p.Outputf(p.err, format, a...)
+	p.outputf(LevelError, p.err, format, a...)
 }
 
 func (p Log) Error(args ...interface{}) {
-	p.Output(p.err, args...)
+	p.output(LevelError, p.err, args...)
+}
+
+// Debug logs a debug message, lower severity than Info.
+func (p Log) Debug(a ...interface{}) {
+	p.output(LevelDebug, p.info, a...)
+}
+
+// Debugf logs a formatted debug message.
+func (p Log) Debugf(format string, a ...interface{}) {
+	p.outputf(LevelDebug, p.info, format, a...)
+}
+
+// Warn logs a warning message, higher severity than Info.
+func (p Log) Warn(a ...interface{}) {
+	p.output(LevelWarn, p.err, a...)
+}
+
+// Warnf logs a formatted warning message.
+func (p Log) Warnf(format string, a ...interface{}) {
+	p.outputf(LevelWarn, p.err, format, a...)
 }
 
 // Close closes the all logfile.
 func Close() {
+	stopAsync()
+	SyncHooks()
 	for _, w := range logOut {
 		w.Close()
 	}
@@ -216,6 +321,8 @@ func Close() {
 
 // Rotate closes All files, moves it aside with a timestamp in the name,
 func Rotate() {
+	flushAsync()
+	SyncHooks()
 	for _, w := range logOut {
 		w.Rotate()
 	}
@@ -230,35 +337,34 @@ func newOut(level int, name, prefix string) *Write {
 	} else {
 		filename = append(filename, fmt.Sprintf("%s_%s_%.2d-%d.log", program, name, level, pid))
 	}
-	return &Write{&lumberjack.Logger{
-		Filename:   filepath.Join(filename...),
-		MaxSize:    maxSize,
-		MaxBackups: maxBackups,
-		MaxAge:     maxAge,
-		Compress:   true,
-	}}
+	return &Write{
+		out: &lumberjack.Logger{
+			Filename:   filepath.Join(filename...),
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			MaxAge:     maxAge,
+			Compress:   true,
+		},
+		async: defaultAsync,
+	}
 }
 
 // SetOutput sets the output destination for the logger.
 func SetOutput(level int, prefix string, info, error io.Writer) {
+	setOut(level, "info", prefix, info)
+	setOut(level, "error", prefix, error)
+}
+
+// setOut sets the single output slot identified by the "<level>_<name>_<prefix>"
+// key that getOut/newOut derive, to w.
+func setOut(level int, name, prefix string, w io.Writer) {
 	lock.Lock()
 	defer lock.Unlock()
-	var (
-		key string
-		out *Write
-	)
-
-	key = fmt.Sprintf("%d_info_%s", level, prefix)
-	if out = logOut[key]; out != nil {
-		out.out = info
-	} else {
-		logOut[key] = &Write{info}
-	}
-	key = fmt.Sprintf("%d_error_%s", level, prefix)
-	if out = logOut[key]; out != nil {
-		out.out = error
+	key := fmt.Sprintf("%d_%s_%s", level, name, prefix)
+	if out := logOut[key]; out != nil {
+		out.out = w
 	} else {
-		logOut[key] = &Write{error}
+		logOut[key] = &Write{out: w, async: defaultAsync}
 	}
 }
 func getOut(level int, name, prefix string) *Write {
@@ -290,6 +396,18 @@ func Printf(format string, a ...interface{}) {
 func Print(a ...interface{}) {
 	log.Print(a...)
 }
+func Debugf(format string, a ...interface{}) {
+	log.Debugf(format, a...)
+}
+func Debug(a ...interface{}) {
+	log.Debug(a...)
+}
+func Warnf(format string, a ...interface{}) {
+	log.Warnf(format, a...)
+}
+func Warn(a ...interface{}) {
+	log.Warn(a...)
+}
 
 // Fatal is equivalent to l.Print() followed by a call to os.Exit(1).
 func Fatal(a ...interface{}) {
@@ -1,8 +1,14 @@
 package log
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -20,9 +26,132 @@ func TestLog_Print(t *testing.T) {
 
 	tmp.V(2).Print("abc")
 	tmp.Error("abc")
+	tmp.Debug("abc")
+	tmp.Warn("abc")
 	Rotate()
 
 }
+
+func TestJSONFormatter_Fields(t *testing.T) {
+	entry := &Entry{Time: "t", Message: "hello", Fields: map[string]interface{}{"user": "alice", "n": float64(3)}}
+	var buf bytes.Buffer
+	if err := (&JSONFormatter{}).Format(entry, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["msg"] != "hello" || got["user"] != "alice" || got["n"] != float64(3) {
+		t.Fatalf("expected fields in rendered JSON output, got %v", got)
+	}
+}
+
+func TestTextFormatter_Fields(t *testing.T) {
+	entry := &Entry{Time: "t", Message: "hello", Fields: map[string]interface{}{"user": "alice"}}
+	var buf bytes.Buffer
+	if err := (&TextFormatter{}).Format(entry, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "user=alice") {
+		t.Fatalf("expected field in rendered text output, got %q", buf.String())
+	}
+}
+
+func TestLog_WithField(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(0, "")
+	parent.WithFormatter(&JSONFormatter{})
+
+	child := parent.WithField("user", "alice").(*Log)
+	child.Output(&buf, "hello")
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["msg"] != "hello" || got["user"] != "alice" {
+		t.Fatalf("expected WithField to thread into the formatted entry, got %v", got)
+	}
+
+	if len(parent.Fields) != 0 {
+		t.Fatalf("expected WithField on a child not to mutate the parent's Fields, got %v", parent.Fields)
+	}
+}
+
+func TestLog_WithFields(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(0, "").WithField("service", "api").(*Log)
+	parent.WithFormatter(&JSONFormatter{})
+
+	child := parent.WithFields(map[string]interface{}{"user": "bob", "n": 3}).(*Log)
+	child.Output(&buf, "hello")
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["service"] != "api" || got["user"] != "bob" || got["n"] != float64(3) {
+		t.Fatalf("expected WithFields to merge with the parent's fields, got %v", got)
+	}
+
+	if _, ok := parent.Fields["user"]; ok {
+		t.Fatalf("expected WithFields on a child not to mutate the parent's Fields, got %v", parent.Fields)
+	}
+}
+
+func TestLog_ConsoleFormatter(t *testing.T) {
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+	tmp := New(0, "")
+	tmp.WithFormatter(&ConsoleFormatter{ForceColors: true, TimestampFormat: "15:04:05"})
+	tmp.Print("hello")
+	tmp.Error("boom")
+}
+
+func TestLog_VModule(t *testing.T) {
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+	if err := SetVModule("log_test.go=9"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetVModule("")
+
+	// This test runs in-package, so the real call site (this line) is
+	// indistinguishable from log.go's own frames to callerFramePC's
+	// package-boundary walk; exercise vmoduleLevel directly against this
+	// frame's actual pc instead, which is what verbosityEnabled ultimately
+	// calls once it has resolved a caller frame.
+	pc, _, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+	if level, matched := vmoduleLevel(pc); !matched || level != 9 {
+		t.Fatalf("expected log_test.go to match the vmodule override at level 9, got level=%d matched=%v", level, matched)
+	}
+
+	V(5).Print("in-package call site, gated by the global level rather than vmodule")
+}
+
+func TestVModuleMatch(t *testing.T) {
+	cases := []struct {
+		glob, file string
+		want       bool
+	}{
+		{"db.go", "/root/module/cache/db.go", true},
+		{"*.go", "/root/module/cache/db.go", true},
+		{"cache/db.go", "/root/module/cache/db.go", true},
+		{"http/*", "/root/module/http/server.go", true},
+		{"http/*", "/root/module/cache/db.go", false},
+		{"cache/db.go", "/root/module/other/db.go", false},
+		{"a/b/db.go", "/root/module/cache/db.go", false},
+	}
+	for _, c := range cases {
+		if got := vmoduleMatch(c.glob, c.file); got != c.want {
+			t.Errorf("vmoduleMatch(%q, %q) = %v, want %v", c.glob, c.file, got, c.want)
+		}
+	}
+}
+
 func BenchmarkDummyLogger(b *testing.B) {
 	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
 	b.RunParallel(func(pb *testing.PB) {
@@ -42,3 +171,96 @@ func BenchmarkDummyJSONLogger(b *testing.B) {
 		}
 	})
 }
+
+func TestLog_Async(t *testing.T) {
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+	SetAsync(16, DropOldest)
+	defer stopAsync()
+
+	for i := 0; i < 100; i++ {
+		Info("async", i)
+	}
+	if err := defaultAsync.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stats := defaultAsync.Stats(); stats.Flushed+stats.Dropped != stats.Queued {
+		t.Fatalf("unexpected async stats: %+v", stats)
+	}
+}
+
+func BenchmarkDummyLoggerAsync(b *testing.B) {
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+	SetAsync(1024, Block)
+	defer SetAsync(0, Block)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			Info("https://github.com/notifications")
+		}
+	})
+}
+
+func TestLog_Configure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "configured.log")
+	cfg := fmt.Sprintf(`{
+		"level": 2,
+		"formatter": "json",
+		"outputs": [
+			{"name": "file", "type": "file", "level": 0, "path": %q}
+		]
+	}`, path)
+	if err := Configure(strings.NewReader(cfg), "json"); err != nil {
+		t.Fatal(err)
+	}
+	Info("configured via json")
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "configured via json") {
+		t.Fatalf("expected the configured output to receive the entry, got %q", data)
+	}
+
+	if _, err := buildOutput(OutputConfig{Type: "bogus"}); err == nil {
+		t.Fatal("expected error for unknown output type")
+	}
+}
+
+func TestLog_Configure_FanOut(t *testing.T) {
+	pathA := filepath.Join(t.TempDir(), "a.log")
+	pathB := filepath.Join(t.TempDir(), "b.log")
+	cfg := fmt.Sprintf(`{
+		"level": 0,
+		"outputs": [
+			{"name": "a", "type": "file", "level": 0, "path": %q},
+			{"name": "b", "type": "file", "level": 0, "path": %q}
+		]
+	}`, pathA, pathB)
+	if err := Configure(strings.NewReader(cfg), "json"); err != nil {
+		t.Fatal(err)
+	}
+	Info("fan out to both files")
+
+	for _, path := range []string{pathA, pathB} {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "fan out to both files") {
+			t.Fatalf("expected %s to receive the entry, got %q", path, data)
+		}
+	}
+}
+
+func BenchmarkDummyLoggerWithCaller(b *testing.B) {
+	SetOutput(0, "", ioutil.Discard, ioutil.Discard)
+	tmp := New(0, "")
+	tmp.ReportCaller = true
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			tmp.Print("https://github.com/notifications")
+		}
+	})
+}
@@ -2,8 +2,10 @@ package log
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/valyala/bytebufferpool"
 	"io"
+	"sort"
 )
 
 type TextFormatter struct {
@@ -19,6 +21,23 @@ func (p *TextFormatter) Format(entry *Entry, w io.Writer) error {
 	buf.Write([]byte(entry.Time))
 	buf.Write([]byte("\t"))
 	buf.Write(b)
+	if entry.Caller != nil {
+		buf.Write([]byte(" "))
+		buf.Write([]byte(fmt.Sprintf("caller=%s:%d", entry.Caller.File, entry.Caller.Line)))
+	}
+	if len(entry.Fields) > 0 {
+		keys := make([]string, 0, len(entry.Fields))
+		for k := range entry.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf.Write([]byte(" "))
+			buf.Write([]byte(k))
+			buf.Write([]byte("="))
+			buf.Write([]byte(fmt.Sprint(entry.Fields[k])))
+		}
+	}
 	buf.Write([]byte("\n"))
 	_, err = w.Write(buf.B)
 	p.pool.Put(buf)